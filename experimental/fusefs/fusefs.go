@@ -0,0 +1,346 @@
+//go:build linux || darwin
+
+// Package fusefs serves a wazero fsapi.FS (the same abstraction backing WASI
+// preopens) as a FUSE filesystem, so operators can `ls`/`cat` the virtual
+// filesystem a running guest sees, or script host tooling against it.
+//
+// This package depends on github.com/hanwen/go-fuse/v2, which is not a
+// dependency of the core wazero module: only programs that import
+// experimental/fusefs pull it in.
+package fusefs
+
+import (
+	"context"
+	"syscall"
+
+	"github.com/hanwen/go-fuse/v2/fs"
+	"github.com/hanwen/go-fuse/v2/fuse"
+
+	experimentalsys "github.com/tetratelabs/wazero/experimental/sys"
+	"github.com/tetratelabs/wazero/internal/fsapi"
+	"github.com/tetratelabs/wazero/sys"
+)
+
+// Server mounts an fsapi.FS at a host directory until Unmount or Close is
+// called.
+type Server struct {
+	server *fuse.Server
+}
+
+// Mount serves `fsys` as a FUSE filesystem at `mountpoint`, returning once
+// the mount is ready to serve requests. Call Unmount, or Close, to tear it
+// down; a Server left running past process exit leaves the mountpoint
+// wedged until a manual `umount`.
+func Mount(fsys fsapi.FS, mountpoint string, opts Options) (*Server, error) {
+	root := &node{fs: fsys}
+	server, err := fs.Mount(mountpoint, root, &fs.Options{
+		MountOptions: fuse.MountOptions{AllowOther: opts.AllowOther, Debug: opts.Debug},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Server{server: server}, nil
+}
+
+// Unmount unmounts the filesystem. It is safe to call concurrently with
+// Wait, and more than once.
+func (s *Server) Unmount() error {
+	return s.server.Unmount()
+}
+
+// Close is an alias for Unmount, so *Server satisfies io.Closer for
+// embedders that defer-close their mounted filesystems.
+func (s *Server) Close() error {
+	return s.Unmount()
+}
+
+// Wait blocks until the filesystem is unmounted, either by Unmount or by
+// the host OS (e.g. `umount` run out-of-process).
+func (s *Server) Wait() {
+	s.server.Wait()
+}
+
+// node implements the go-fuse high-level node API (fs.InodeEmbedder and
+// friends) over a single path in an fsapi.FS. Each LOOKUP creates a child
+// node rather than resolving the whole tree up front, matching how
+// fsapi.FS itself resolves one path component at a time.
+type node struct {
+	fs.Inode
+
+	fs   fsapi.FS
+	path string
+}
+
+var (
+	_ fs.NodeLookuper  = (*node)(nil)
+	_ fs.NodeGetattrer = (*node)(nil)
+	_ fs.NodeSetattrer = (*node)(nil)
+	_ fs.NodeOpener    = (*node)(nil)
+	_ fs.NodeReaddirer = (*node)(nil)
+	_ fs.NodeMkdirer   = (*node)(nil)
+	_ fs.NodeUnlinker  = (*node)(nil)
+	_ fs.NodeRmdirer   = (*node)(nil)
+	_ fs.NodeRenamer   = (*node)(nil)
+)
+
+// errnoToFuse maps a sys.Errno to the POSIX errno FUSE expects in a reply.
+//
+// experimentalsys.Errno is wazero's own sequential enumeration, not a
+// POSIX errno number, so this cannot be a numeric cast; it must name each
+// case explicitly, the same way experimentalsys.UnwrapOSError does in the
+// opposite direction.
+func errnoToFuse(errno experimentalsys.Errno) syscall.Errno {
+	switch errno {
+	case 0:
+		return fs.OK
+	case experimentalsys.EACCES:
+		return syscall.EACCES
+	case experimentalsys.EAGAIN:
+		return syscall.EAGAIN
+	case experimentalsys.EBADF:
+		return syscall.EBADF
+	case experimentalsys.EEXIST:
+		return syscall.EEXIST
+	case experimentalsys.EFAULT:
+		return syscall.EFAULT
+	case experimentalsys.EFBIG:
+		return syscall.EFBIG
+	case experimentalsys.EINTR:
+		return syscall.EINTR
+	case experimentalsys.EINVAL:
+		return syscall.EINVAL
+	case experimentalsys.EIO:
+		return syscall.EIO
+	case experimentalsys.EISDIR:
+		return syscall.EISDIR
+	case experimentalsys.ELOOP:
+		return syscall.ELOOP
+	case experimentalsys.ENAMETOOLONG:
+		return syscall.ENAMETOOLONG
+	case experimentalsys.ENOENT:
+		return syscall.ENOENT
+	case experimentalsys.ENOSYS:
+		return syscall.ENOSYS
+	case experimentalsys.ENOTDIR:
+		return syscall.ENOTDIR
+	case experimentalsys.ENOTEMPTY:
+		return syscall.ENOTEMPTY
+	case experimentalsys.ENOTSOCK:
+		return syscall.ENOTSOCK
+	case experimentalsys.ENOTSUP:
+		return syscall.ENOTSUP
+	case experimentalsys.ENOTTY:
+		return syscall.ENOTTY
+	case experimentalsys.ENXIO:
+		return syscall.ENXIO
+	case experimentalsys.EPERM:
+		return syscall.EPERM
+	case experimentalsys.ERANGE:
+		return syscall.ERANGE
+	case experimentalsys.EROFS:
+		return syscall.EROFS
+	case experimentalsys.ETIMEDOUT:
+		return syscall.ETIMEDOUT
+	case experimentalsys.ETXTBSY:
+		return syscall.ETXTBSY
+	case experimentalsys.EXDEV:
+		return syscall.EXDEV
+	default:
+		// Unknown to this mapping: EIO is the generic "something went
+		// wrong" POSIX errno, and is far less misleading to the kernel
+		// than handing back errno's own raw enum value.
+		return syscall.EIO
+	}
+}
+
+func childPath(parent, name string) string {
+	if parent == "" || parent == "." {
+		return name
+	}
+	return parent + "/" + name
+}
+
+// Lookup implements fs.NodeLookuper
+func (n *node) Lookup(ctx context.Context, name string, out *fuse.EntryOut) (*fs.Inode, syscall.Errno) {
+	p := childPath(n.path, name)
+	st, errno := n.fs.Stat(p)
+	if errno != 0 {
+		return nil, errnoToFuse(errno)
+	}
+
+	fillAttrOut(st, &out.Attr)
+	child := &node{fs: n.fs, path: p}
+	mode := fuse.S_IFREG
+	if st.Mode.IsDir() {
+		mode = fuse.S_IFDIR
+	}
+	return n.NewInode(ctx, child, fs.StableAttr{Mode: uint32(mode)}), fs.OK
+}
+
+// Getattr implements fs.NodeGetattrer
+func (n *node) Getattr(_ context.Context, _ fs.FileHandle, out *fuse.AttrOut) syscall.Errno {
+	st, errno := n.fs.Stat(n.path)
+	if errno != 0 {
+		return errnoToFuse(errno)
+	}
+	fillAttrOut(st, &out.Attr)
+	return fs.OK
+}
+
+// Setattr implements fs.NodeSetattrer
+//
+// Only a requested modification time is applied: the path is opened with
+// fsapi.O_RDWR and fsapi.File.Utimens is called with that time and an
+// access time of UTIME_NOW. If the open fails, or Utimens returns ENOSYS
+// (as the read-only adapters such as embed.FS do), the change is skipped
+// and no error is returned; any other Utimens error is returned. Size,
+// mode, ownership and a requested access time are not applied. Otherwise
+// the reply carries the current attributes from Getattr.
+func (n *node) Setattr(ctx context.Context, f fs.FileHandle, in *fuse.SetAttrIn, out *fuse.AttrOut) syscall.Errno {
+	if mtime, ok := in.GetMTime(); ok {
+		times := [2]syscall.Timespec{utimeNow(), {Sec: mtime.Unix(), Nsec: int64(mtime.Nanosecond())}}
+		if file, errno := n.fs.OpenFile(n.path, fsapi.O_RDWR, 0); errno == 0 {
+			defer file.Close()
+			if errno := file.Utimens(&times); errno != 0 && errno != experimentalsys.ENOSYS {
+				return errnoToFuse(errno)
+			}
+		}
+	}
+	return n.Getattr(ctx, f, out)
+}
+
+// Open implements fs.NodeOpener
+func (n *node) Open(_ context.Context, flags uint32) (fs.FileHandle, uint32, syscall.Errno) {
+	file, errno := n.fs.OpenFile(n.path, int(flags), 0)
+	if errno != 0 {
+		return nil, 0, errnoToFuse(errno)
+	}
+	return &fileHandle{file: file}, 0, fs.OK
+}
+
+// Readdir implements fs.NodeReaddirer
+func (n *node) Readdir(_ context.Context) (fs.DirStream, syscall.Errno) {
+	file, errno := n.fs.OpenFile(n.path, fsapi.O_RDONLY, 0)
+	if errno != 0 {
+		return nil, errnoToFuse(errno)
+	}
+	defer file.Close()
+
+	dirents, errno := file.Readdir(-1)
+	if errno != 0 {
+		return nil, errnoToFuse(errno)
+	}
+
+	entries := make([]fuse.DirEntry, len(dirents))
+	for i, d := range dirents {
+		mode := fuse.S_IFREG
+		if d.Type.IsDir() {
+			mode = fuse.S_IFDIR
+		}
+		entries[i] = fuse.DirEntry{Name: d.Name, Mode: uint32(mode)}
+	}
+	return fs.NewListDirStream(entries), fs.OK
+}
+
+// Mkdir implements fs.NodeMkdirer
+func (n *node) Mkdir(ctx context.Context, name string, mode uint32, out *fuse.EntryOut) (*fs.Inode, syscall.Errno) {
+	return nil, syscall.EROFS
+}
+
+// Unlink implements fs.NodeUnlinker
+func (n *node) Unlink(context.Context, string) syscall.Errno {
+	return syscall.EROFS
+}
+
+// Rmdir implements fs.NodeRmdirer
+func (n *node) Rmdir(context.Context, string) syscall.Errno {
+	return syscall.EROFS
+}
+
+// Rename implements fs.NodeRenamer
+func (n *node) Rename(context.Context, string, fs.InodeEmbedder, string, uint32) syscall.Errno {
+	return syscall.EROFS
+}
+
+// fileHandle implements the go-fuse FileHandle operations, dispatching to
+// fsapi.File.
+type fileHandle struct {
+	file fsapi.File
+}
+
+var (
+	_ fs.FileReader         = (*fileHandle)(nil)
+	_ fs.FileWriter         = (*fileHandle)(nil)
+	_ fs.FileFsyncer        = (*fileHandle)(nil)
+	_ fs.FileReleaser       = (*fileHandle)(nil)
+	_ fs.FileCopyFileRanger = (*fileHandle)(nil)
+)
+
+// Read implements fs.FileReader
+func (h *fileHandle) Read(_ context.Context, dest []byte, off int64) (fuse.ReadResult, syscall.Errno) {
+	n, errno := h.file.Pread(dest, off)
+	if errno != 0 {
+		return nil, errnoToFuse(errno)
+	}
+	return fuse.ReadResultData(dest[:n]), fs.OK
+}
+
+// Write implements fs.FileWriter
+func (h *fileHandle) Write(_ context.Context, data []byte, off int64) (uint32, syscall.Errno) {
+	n, errno := h.file.Pwrite(data, off)
+	if errno != 0 {
+		return 0, errnoToFuse(errno)
+	}
+	return uint32(n), fs.OK
+}
+
+// Fsync implements fs.FileFsyncer
+func (h *fileHandle) Fsync(context.Context, uint32) syscall.Errno {
+	return errnoToFuse(h.file.Sync())
+}
+
+// Release implements fs.FileReleaser
+func (h *fileHandle) Release(context.Context) syscall.Errno {
+	return errnoToFuse(h.file.Close())
+}
+
+// CopyFileRange implements fs.FileCopyFileRanger, giving the kernel's
+// copy_file_range(2) a host-side path that avoids bouncing bytes through a
+// userspace buffer: it forwards straight to fsapi.CopyFile, which prefers
+// the underlying File.CopyRange fast path and falls back to a buffered
+// Pread/Pwrite loop when that's unavailable.
+func (h *fileHandle) CopyFileRange(_ context.Context, _ fs.FileHandle, offIn uint64, fhOut fs.FileHandle, offOut uint64, length uint64, _ uint64) (uint32, syscall.Errno) {
+	out, ok := fhOut.(*fileHandle)
+	if !ok {
+		return 0, syscall.ENOSYS
+	}
+
+	n, errno := fsapi.CopyFile(out.file, int64(offOut), h.file, int64(offIn), int64(length))
+	if errno != 0 {
+		return 0, errnoToFuse(errno)
+	}
+	return uint32(n), fs.OK
+}
+
+func fillAttrOut(st sys.Stat_t, attr *fuse.Attr) {
+	attr.Size = uint64(st.Size)
+	attr.Mode = uint32(st.Mode.Perm())
+	if st.Mode.IsDir() {
+		attr.Mode |= fuse.S_IFDIR
+	} else {
+		attr.Mode |= fuse.S_IFREG
+	}
+	attr.Nlink = uint32(st.Nlink)
+	attr.Mtime = uint64(st.Mtim / 1e9)
+	attr.Mtimensec = uint32(st.Mtim % 1e9)
+	attr.Atime = uint64(st.Atim / 1e9)
+	attr.Atimensec = uint32(st.Atim % 1e9)
+}
+
+func utimeNow() syscall.Timespec {
+	return syscall.Timespec{Nsec: utimeNowNsec}
+}
+
+// utimeNowNsec mirrors the UTIME_NOW sentinel documented on
+// fsapi.File.Utimens.
+const utimeNowNsec = (1 << 30) - 1
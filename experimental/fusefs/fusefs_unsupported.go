@@ -0,0 +1,28 @@
+//go:build !(linux || darwin)
+
+package fusefs
+
+import (
+	"errors"
+
+	"github.com/tetratelabs/wazero/internal/fsapi"
+)
+
+// Server is declared here too so callers on unsupported platforms can still
+// reference the type (e.g. in a struct field) without build-tagging their
+// own code.
+type Server struct{}
+
+// Mount always fails on platforms without a supported FUSE implementation.
+func Mount(fsapi.FS, string, Options) (*Server, error) {
+	return nil, errors.New("fusefs: unsupported on this platform")
+}
+
+// Unmount is a no-op given Mount never succeeds on this platform.
+func (s *Server) Unmount() error { return nil }
+
+// Close is an alias for Unmount.
+func (s *Server) Close() error { return nil }
+
+// Wait is a no-op given Mount never succeeds on this platform.
+func (s *Server) Wait() {}
@@ -0,0 +1,16 @@
+package fusefs
+
+// Options configures Mount. The zero value is a mount visible only to the
+// mounting user, matching go-fuse's own defaults. File contents can be
+// written where the fsapi.FS allows it, but MKDIR, UNLINK, RMDIR and
+// RENAME always fail with EROFS.
+type Options struct {
+	// AllowOther permits users other than the one that issued the mount to
+	// access the filesystem. This generally requires `user_allow_other` in
+	// /etc/fuse.conf, or running as root.
+	AllowOther bool
+
+	// Debug logs every FUSE request and reply to stderr. This is
+	// exceptionally verbose; use only to debug fusefs itself.
+	Debug bool
+}
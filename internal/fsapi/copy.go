@@ -0,0 +1,69 @@
+package fsapi
+
+import experimentalsys "github.com/tetratelabs/wazero/experimental/sys"
+
+// copyBufferSize is the chunk size used by CopyFile when no zero-copy
+// fast-path is available. This matches the buffer size io.Copy defaults to
+// internally, which is large enough to amortize syscall overhead without
+// pinning an excessive amount of memory per splice.
+const copyBufferSize = 32 * 1024
+
+// CopyFile copies up to `n` bytes from `src` at `srcOff` to `dst` at
+// `dstOff`, preferring `src.CopyRange` and falling back to a buffered
+// Pread/Pwrite loop when the fast-path is unavailable or only partially
+// successful.
+//
+// # Notes
+//
+//   - experimental/fusefs's fileHandle.CopyFileRange calls this to serve the
+//     kernel's copy_file_range(2) without bouncing bytes through a
+//     userspace buffer; a future WASI host-defined "splice" function could
+//     call it the same way.
+//   - `srcOff` and `dstOff` must be non-negative; offset==-1 "current
+//     position" semantics are the responsibility of File.CopyRange
+//     implementations, not this helper.
+//   - A sys.ENOSYS or sys.EXDEV from CopyRange, or a CopyRange that copied
+//     fewer than `n` bytes, is continued by the buffered loop from where
+//     CopyRange stopped instead of being returned to the caller.
+func CopyFile(dst File, dstOff int64, src File, srcOff int64, n int64) (copied int64, errno experimentalsys.Errno) {
+	if n == 0 {
+		return 0, 0
+	}
+
+	copied, errno = src.CopyRange(srcOff, dst, dstOff, n)
+	if errno != 0 && errno != experimentalsys.ENOSYS && errno != experimentalsys.EXDEV {
+		return copied, errno
+	} else if copied >= n {
+		return copied, 0
+	}
+
+	// Fall back to a buffered Pread/Pwrite loop. This never disturbs either
+	// file's shared cursor, matching the semantics of a successful
+	// CopyRange.
+	buf := make([]byte, copyBufferSize)
+	remaining := n - copied
+	for remaining > 0 {
+		readSize := int64(len(buf))
+		if remaining < readSize {
+			readSize = remaining
+		}
+
+		nr, errno := src.Pread(buf[:readSize], srcOff+copied)
+		if nr > 0 {
+			if nw, errno := dst.Pwrite(buf[:nr], dstOff+copied); errno != 0 {
+				return copied + int64(nw), errno
+			} else if nw != nr {
+				return copied + int64(nw), experimentalsys.EIO
+			}
+			copied += int64(nr)
+			remaining -= int64(nr)
+		}
+		if errno != 0 {
+			return copied, errno
+		}
+		if nr == 0 {
+			break // source is at EOF
+		}
+	}
+	return copied, 0
+}
@@ -370,4 +370,41 @@ type File interface {
 	//   - This is like syscall.Close and `close` in POSIX. See
 	//     https://pubs.opengroup.org/onlinepubs/9699919799/functions/close.html
 	Close() experimentalsys.Errno
+
+	// CopyRange copies `n` bytes starting at offset `srcOff` in this file to
+	// `dst` starting at offset `dstOff`, without an intermediate copy through
+	// WebAssembly linear memory, and returns the count copied even on error.
+	//
+	// # Parameters
+	//
+	// An `srcOff` or `dstOff` of -1 means the current position of the
+	// respective file should be used instead of a fixed offset, and that
+	// file's position is advanced by the count copied, the same as Read or
+	// Write would. A non-negative offset instead dispatches to Pread/Pwrite
+	// semantics and leaves the file's position untouched.
+	//
+	// # Errors
+	//
+	// A zero sys.Errno is success. The below are expected otherwise:
+	//   - sys.ENOSYS: the implementation does not support this function.
+	//   - sys.EBADF: either file was closed, not readable/writeable, or a
+	//     directory.
+	//   - sys.EISDIR: either file was a directory.
+	//   - sys.EXDEV: the files reside on different filesystems and no
+	//     fallback copy was possible.
+	//
+	// # Notes
+	//
+	//   - This is like Linux `copy_file_range(2)`, except scoped to a single
+	//     pair of files instead of arbitrary file descriptors. See
+	//     https://man7.org/linux/man-pages/man2/copy_file_range.2.html
+	//   - Implementations return sys.ENOSYS, instead of copying through a
+	//     buffer, when no platform-specific zero-copy primitive is available.
+	//     fsapi.CopyFile wraps this with a buffered Pread/Pwrite loop, which
+	//     also continues after a short copy or sys.EXDEV.
+	//   - Callers wanting offset==-1 semantics on both sides concurrently
+	//     from multiple goroutines should prefer Pread/Pwrite with explicit
+	//     offsets, as this shares the same racy cursor semantics as Read and
+	//     Write.
+	CopyRange(srcOff int64, dst File, dstOff int64, n int64) (copied int64, errno experimentalsys.Errno)
 }
@@ -0,0 +1,214 @@
+package fsapi
+
+import (
+	"io"
+
+	experimentalsys "github.com/tetratelabs/wazero/experimental/sys"
+)
+
+// fileView is the shared state behind NewReader, NewWriter, and their *At
+// and Section variants: a File plus an offset that advances independently
+// of the File's own shared cursor, analogous to gvisor's lockedReader and
+// lockedWriter.
+type fileView struct {
+	f     File
+	off   int64
+	owned bool
+}
+
+// errnoToError translates an experimentalsys.Errno to an error usable with
+// io.Reader/io.Writer, folding the "zero-errno, zero-n" end-of-file
+// convention used by File.Pread/File.Read into io.EOF.
+func errnoToError(n int, errno experimentalsys.Errno, eofOnZero bool) error {
+	if errno != 0 {
+		return errno
+	}
+	if eofOnZero && n == 0 {
+		return io.EOF
+	}
+	return nil
+}
+
+// writeErrnoToError is errnoToError for Pwrite-backed callers: io.Writer
+// and io.WriterAt both require a non-nil error whenever n is less than the
+// requested length, even if Pwrite itself reported a zero errno, so a
+// short write isn't mistaken by callers like io.Copy for full success.
+func writeErrnoToError(n, want int, errno experimentalsys.Errno) error {
+	if errno != 0 {
+		return errno
+	}
+	if n < want {
+		return io.ErrShortWrite
+	}
+	return nil
+}
+
+// sync is the Sync passthrough shared by reader and writer views below.
+func (v *fileView) sync() error {
+	if errno := v.f.Sync(); errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// close only closes the underlying File if this view owns it, so that
+// multiple views over the same File can coexist without fighting over who
+// gets to close it.
+func (v *fileView) close() error {
+	if !v.owned {
+		return nil
+	}
+	if errno := v.f.Close(); errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// reader is an io.Reader, io.ReaderAt, io.Closer that reads from a File at
+// an offset it tracks itself, via File.Pread, instead of mutating the
+// File's shared cursor the way File.Read + File.Seek would.
+type reader struct {
+	fileView
+}
+
+// NewReader returns an io.Reader over `f` that begins at `off` and
+// advances its own offset on each Read, without touching `f`'s shared
+// cursor (unlike a Read/Seek-based reader, which would race with any other
+// consumer of `f`, including WASI's own fd position).
+//
+// The returned value also implements io.ReaderAt, io.Closer, and a Sync
+// method; Close is a no-op unless the returned reader is passed to
+// ReaderOwned.
+func NewReader(f File, off int64) io.Reader {
+	return &reader{fileView{f: f, off: off}}
+}
+
+// ReaderOwned marks a reader returned by NewReader as owning its
+// underlying File, so that calling Close on the returned value also closes
+// the File.
+func ReaderOwned(r io.Reader) io.Reader {
+	if rd, ok := r.(*reader); ok {
+		rd.owned = true
+	}
+	return r
+}
+
+// Read implements io.Reader
+func (r *reader) Read(p []byte) (int, error) {
+	n, errno := r.f.Pread(p, r.off)
+	r.off += int64(n)
+	return n, errnoToError(n, errno, true)
+}
+
+// ReadAt implements io.ReaderAt
+func (r *reader) ReadAt(p []byte, off int64) (int, error) {
+	n, errno := r.f.Pread(p, off)
+	if err := errnoToError(n, errno, true); err != nil {
+		return n, err
+	} else if n < len(p) {
+		return n, io.EOF // io.ReaderAt requires an error on a short read
+	}
+	return n, nil
+}
+
+// Sync synchronizes changes to the underlying File. See File.Sync.
+func (r *reader) Sync() error { return r.sync() }
+
+// Close implements io.Closer. This only closes the underlying File if this
+// reader was returned from ReaderOwned.
+func (r *reader) Close() error { return r.close() }
+
+// writer is an io.Writer, io.WriterAt, io.Closer that writes to a File at
+// an offset it tracks itself, via File.Pwrite, instead of mutating the
+// File's shared cursor the way File.Write + File.Seek would.
+type writer struct {
+	fileView
+}
+
+// NewWriter returns an io.Writer over `f` that begins at `off` and
+// advances its own offset on each Write, without touching `f`'s shared
+// cursor. See NewReader for why this matters when `f` is shared.
+//
+// The returned value also implements io.WriterAt, io.Closer, and a Sync
+// method; Close is a no-op unless the returned writer is passed to
+// WriterOwned.
+func NewWriter(f File, off int64) io.Writer {
+	return &writer{fileView{f: f, off: off}}
+}
+
+// WriterOwned marks a writer returned by NewWriter as owning its
+// underlying File, so that calling Close on the returned value also closes
+// the File.
+func WriterOwned(w io.Writer) io.Writer {
+	if wr, ok := w.(*writer); ok {
+		wr.owned = true
+	}
+	return w
+}
+
+// Write implements io.Writer
+func (w *writer) Write(p []byte) (int, error) {
+	n, errno := w.f.Pwrite(p, w.off)
+	w.off += int64(n)
+	return n, writeErrnoToError(n, len(p), errno)
+}
+
+// WriteAt implements io.WriterAt
+func (w *writer) WriteAt(p []byte, off int64) (int, error) {
+	n, errno := w.f.Pwrite(p, off)
+	return n, writeErrnoToError(n, len(p), errno)
+}
+
+// Sync synchronizes changes to the underlying File. See File.Sync.
+func (w *writer) Sync() error { return w.sync() }
+
+// Close implements io.Closer. This only closes the underlying File if this
+// writer was returned from WriterOwned.
+func (w *writer) Close() error { return w.close() }
+
+// NewReaderAt returns an io.ReaderAt over `f` that dispatches every call
+// directly to File.Pread with the caller-supplied offset, carrying no
+// offset state of its own.
+func NewReaderAt(f File) io.ReaderAt {
+	return &readerAt{f}
+}
+
+// NewWriterAt returns an io.WriterAt over `f` that dispatches every call
+// directly to File.Pwrite with the caller-supplied offset, carrying no
+// offset state of its own.
+func NewWriterAt(f File) io.WriterAt {
+	return &writerAt{f}
+}
+
+// writerAt adapts a File to io.WriterAt by way of Pwrite, with no offset
+// tracking of its own.
+type writerAt struct{ f File }
+
+// WriteAt implements io.WriterAt
+func (w *writerAt) WriteAt(p []byte, off int64) (int, error) {
+	n, errno := w.f.Pwrite(p, off)
+	return n, writeErrnoToError(n, len(p), errno)
+}
+
+// NewSectionReader returns an *io.SectionReader that reads the `n` bytes
+// of `f` starting at `off` via File.Pread,
+// without touching `f`'s shared cursor.
+func NewSectionReader(f File, off, n int64) *io.SectionReader {
+	return io.NewSectionReader(&readerAt{f}, off, n)
+}
+
+// readerAt adapts a File to io.ReaderAt by way of Pread, with no offset
+// tracking of its own, for use with io.NewSectionReader and other stdlib
+// helpers that expect io.ReaderAt semantics.
+type readerAt struct{ f File }
+
+// ReadAt implements io.ReaderAt
+func (r *readerAt) ReadAt(p []byte, off int64) (int, error) {
+	n, errno := r.f.Pread(p, off)
+	if err := errnoToError(n, errno, true); err != nil {
+		return n, err
+	} else if n < len(p) {
+		return n, io.EOF // io.ReaderAt requires an error on a short read
+	}
+	return n, nil
+}
@@ -0,0 +1,127 @@
+package fsapi
+
+import (
+	"syscall"
+
+	experimentalsys "github.com/tetratelabs/wazero/experimental/sys"
+	"github.com/tetratelabs/wazero/sys"
+)
+
+// UnimplementedFile is a File that returns sys.ENOSYS for all functions,
+// except where no-op behavior is required, such as Sync.
+//
+// This is embeddable to reduce the amount of functions needed to implement a
+// File. The below is an example of a no-op File:
+//
+//	type ExampleFile struct {
+//		UnimplementedFile
+//	}
+//
+// Most implementations should at least override Close, as it is illegal to
+// call it multiple times.
+type UnimplementedFile struct{}
+
+// Dev implements File.Dev
+func (UnimplementedFile) Dev() (uint64, experimentalsys.Errno) {
+	return 0, 0
+}
+
+// Ino implements File.Ino
+func (UnimplementedFile) Ino() (sys.Inode, experimentalsys.Errno) {
+	return 0, 0
+}
+
+// IsDir implements File.IsDir
+func (UnimplementedFile) IsDir() (bool, experimentalsys.Errno) {
+	return false, 0
+}
+
+// IsAppend implements File.IsAppend
+func (UnimplementedFile) IsAppend() bool {
+	return false
+}
+
+// SetAppend implements File.SetAppend
+func (UnimplementedFile) SetAppend(bool) experimentalsys.Errno {
+	return experimentalsys.ENOSYS
+}
+
+// IsNonblock implements File.IsNonblock
+func (UnimplementedFile) IsNonblock() bool {
+	return false
+}
+
+// SetNonblock implements File.SetNonblock
+func (UnimplementedFile) SetNonblock(bool) experimentalsys.Errno {
+	return experimentalsys.ENOSYS
+}
+
+// Stat implements File.Stat
+func (UnimplementedFile) Stat() (sys.Stat_t, experimentalsys.Errno) {
+	return sys.Stat_t{}, experimentalsys.ENOSYS
+}
+
+// Read implements File.Read
+func (UnimplementedFile) Read([]byte) (int, experimentalsys.Errno) {
+	return 0, experimentalsys.ENOSYS
+}
+
+// Pread implements File.Pread
+func (UnimplementedFile) Pread([]byte, int64) (int, experimentalsys.Errno) {
+	return 0, experimentalsys.ENOSYS
+}
+
+// Seek implements File.Seek
+func (UnimplementedFile) Seek(int64, int) (int64, experimentalsys.Errno) {
+	return 0, experimentalsys.ENOSYS
+}
+
+// PollRead implements File.PollRead
+func (UnimplementedFile) PollRead(timeoutMillis int32) (bool, experimentalsys.Errno) {
+	return false, experimentalsys.ENOSYS
+}
+
+// Readdir implements File.Readdir
+func (UnimplementedFile) Readdir(int) (dirents []Dirent, errno experimentalsys.Errno) {
+	return nil, experimentalsys.ENOSYS
+}
+
+// Write implements File.Write
+func (UnimplementedFile) Write([]byte) (int, experimentalsys.Errno) {
+	return 0, experimentalsys.ENOSYS
+}
+
+// Pwrite implements File.Pwrite
+func (UnimplementedFile) Pwrite([]byte, int64) (int, experimentalsys.Errno) {
+	return 0, experimentalsys.ENOSYS
+}
+
+// Truncate implements File.Truncate
+func (UnimplementedFile) Truncate(int64) experimentalsys.Errno {
+	return experimentalsys.ENOSYS
+}
+
+// Sync implements File.Sync
+func (UnimplementedFile) Sync() experimentalsys.Errno {
+	return 0 // not ENOSYS
+}
+
+// Datasync implements File.Datasync
+func (UnimplementedFile) Datasync() experimentalsys.Errno {
+	return 0 // not ENOSYS
+}
+
+// Utimens implements File.Utimens
+func (UnimplementedFile) Utimens(*[2]syscall.Timespec) experimentalsys.Errno {
+	return experimentalsys.ENOSYS
+}
+
+// CopyRange implements File.CopyRange
+func (UnimplementedFile) CopyRange(int64, File, int64, int64) (int64, experimentalsys.Errno) {
+	return 0, experimentalsys.ENOSYS
+}
+
+// Close implements File.Close
+func (UnimplementedFile) Close() experimentalsys.Errno {
+	return 0
+}
@@ -0,0 +1,8 @@
+//go:build linux && amd64
+
+package sysfs
+
+// sysCopyFileRangeTrap is the copy_file_range(2) syscall number on this
+// GOARCH. See /usr/include/asm-generic/unistd.h or
+// arch/x86/entry/syscalls/syscall_64.tbl in the Linux kernel sources.
+const sysCopyFileRangeTrap = 326
@@ -0,0 +1,8 @@
+//go:build linux && arm64
+
+package sysfs
+
+// sysCopyFileRangeTrap is the copy_file_range(2) syscall number on this
+// GOARCH. See include/uapi/asm-generic/unistd.h in the Linux kernel
+// sources, which arm64 uses directly.
+const sysCopyFileRangeTrap = 285
@@ -0,0 +1,8 @@
+//go:build linux && !amd64 && !arm64
+
+package sysfs
+
+// sysCopyFileRangeTrap is zero on GOARCHes whose copy_file_range(2)
+// syscall number isn't wired up here, so CopyRange falls back to a
+// buffered loop instead of guessing a trap number.
+const sysCopyFileRangeTrap = 0
@@ -0,0 +1,77 @@
+//go:build linux
+
+package sysfs
+
+import (
+	"syscall"
+	"unsafe"
+
+	experimentalsys "github.com/tetratelabs/wazero/experimental/sys"
+	"github.com/tetratelabs/wazero/internal/fsapi"
+)
+
+// CopyRange implements the same method as documented on fsapi.File.
+func (f *osFile) CopyRange(srcOff int64, dst fsapi.File, dstOff int64, n int64) (int64, experimentalsys.Errno) {
+	of, ok := dst.(*osFile)
+	if !ok {
+		// The destination isn't backed by an *os.File we can
+		// copy_file_range into directly (e.g. a synthetic or in-memory
+		// fsapi.File), so defer to fsapi.CopyFile's buffered fallback.
+		return 0, experimentalsys.ENOSYS
+	}
+
+	if sysCopyFileRangeTrap == 0 {
+		// No raw copy_file_range(2) syscall number is known for this
+		// GOARCH: let the caller fall back to a buffered loop.
+		return 0, experimentalsys.ENOSYS
+	}
+
+	srcFd, dstFd := int(f.file.Fd()), int(of.file.Fd())
+
+	var copied int64
+	for copied < n {
+		// copy_file_range advances *off when non-nil; pass explicit
+		// offsets so Pread/Pwrite semantics are preserved unless the
+		// caller requested the file's current position (offset -1).
+		var srcOffP, dstOffP *int64
+		if srcOff >= 0 {
+			off := srcOff + copied
+			srcOffP = &off
+		}
+		if dstOff >= 0 {
+			off := dstOff + copied
+			dstOffP = &off
+		}
+
+		nc, errno := rawCopyFileRange(srcFd, srcOffP, dstFd, dstOffP, n-copied)
+		if errno == syscall.EINTR {
+			continue
+		} else if errno != 0 {
+			if errno == syscall.ENOSYS || errno == syscall.EXDEV || errno == syscall.EINVAL {
+				// Unsupported: cross-filesystem copy, cgroup limits, or a
+				// kernel without copy_file_range. Let the caller fall back
+				// to a buffered loop instead of failing the whole request.
+				if copied == 0 {
+					return 0, experimentalsys.ENOSYS
+				}
+				return copied, 0
+			}
+			return copied, experimentalsys.UnwrapOSError(errno)
+		}
+		if nc == 0 {
+			break // source reached EOF
+		}
+		copied += nc
+	}
+	return copied, 0
+}
+
+// rawCopyFileRange invokes the copy_file_range(2) syscall directly, as it
+// is not exposed by the standard syscall package.
+func rawCopyFileRange(srcFd int, srcOff *int64, dstFd int, dstOff *int64, n int64) (int64, syscall.Errno) {
+	r1, _, errno := syscall.Syscall6(sysCopyFileRangeTrap,
+		uintptr(srcFd), uintptr(unsafe.Pointer(srcOff)),
+		uintptr(dstFd), uintptr(unsafe.Pointer(dstOff)),
+		uintptr(n), 0)
+	return int64(r1), errno
+}
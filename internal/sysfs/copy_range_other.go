@@ -0,0 +1,17 @@
+//go:build !unix
+
+package sysfs
+
+import (
+	experimentalsys "github.com/tetratelabs/wazero/experimental/sys"
+	"github.com/tetratelabs/wazero/internal/fsapi"
+)
+
+// CopyRange implements the same method as documented on fsapi.File.
+//
+// Windows and other non-Unix platforms have no portable zero-copy
+// fd-to-fd primitive wired up here, so this always defers to
+// fsapi.CopyFile's buffered Pread/Pwrite loop.
+func (f *osFile) CopyRange(srcOff int64, dst fsapi.File, dstOff int64, n int64) (int64, experimentalsys.Errno) {
+	return 0, experimentalsys.ENOSYS
+}
@@ -0,0 +1,80 @@
+//go:build unix && !linux
+
+package sysfs
+
+import (
+	"io"
+	"os"
+	"syscall"
+
+	experimentalsys "github.com/tetratelabs/wazero/experimental/sys"
+	"github.com/tetratelabs/wazero/internal/fsapi"
+)
+
+// CopyRange implements the same method as documented on fsapi.File.
+//
+// Unlike Linux, BSD-family sendfile(2) only moves bytes from a regular file
+// into a socket, so this only attempts the zero-copy path when the
+// destination is a socket or named pipe and the source is neither; every
+// other pairing, including a socket source, returns sys.ENOSYS so
+// fsapi.CopyFile falls back to a buffered loop.
+func (f *osFile) CopyRange(srcOff int64, dst fsapi.File, dstOff int64, n int64) (int64, experimentalsys.Errno) {
+	of, ok := dst.(*osFile)
+	if !ok {
+		return 0, experimentalsys.ENOSYS
+	}
+
+	if isSocket(f.file) || !isSocket(of.file) {
+		// sendfile(2) on Darwin/BSD cannot read from a socket, so a socket or
+		// pipe source (or a destination that is neither) has no zero-copy
+		// path here.
+		return 0, experimentalsys.ENOSYS
+	}
+
+	// Only offset==-1 on both sides is supported here; anything else defers
+	// to the buffered fallback. syscall.Sendfile takes an explicit source
+	// offset and leaves the source's position alone, so this starts from the
+	// source's current position and advances it by the count copied.
+	if srcOff >= 0 || dstOff >= 0 {
+		return 0, experimentalsys.ENOSYS
+	}
+
+	off, err := f.file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return 0, experimentalsys.ENOSYS
+	}
+
+	var copied int64
+	for copied < n {
+		remaining := n - copied
+		nc, err := syscall.Sendfile(int(of.file.Fd()), int(f.file.Fd()), &off, int(remaining))
+		if err == syscall.EINTR {
+			continue
+		} else if err != nil {
+			if copied == 0 {
+				return 0, experimentalsys.ENOSYS
+			}
+			_, _ = f.file.Seek(off, io.SeekStart)
+			return copied, experimentalsys.UnwrapOSError(err)
+		}
+		if nc == 0 {
+			break
+		}
+		off += int64(nc)
+		copied += int64(nc)
+	}
+	if _, err := f.file.Seek(off, io.SeekStart); err != nil {
+		return copied, experimentalsys.UnwrapOSError(err)
+	}
+	return copied, 0
+}
+
+// isSocket reports whether `f` is a socket or a named pipe.
+func isSocket(f *os.File) bool {
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	mode := fi.Mode()
+	return mode&(os.ModeSocket|os.ModeNamedPipe) != 0
+}
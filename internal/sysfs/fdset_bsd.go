@@ -0,0 +1,12 @@
+//go:build unix && !linux
+
+package sysfs
+
+import "syscall"
+
+// fdSet sets the bit for `fd` in `set`. On the BSD-family (including
+// Darwin), syscall.FdSet.Bits is indexed in words of 32 bits, unlike Linux's
+// 64-bit words.
+func fdSet(set *syscall.FdSet, fd int) {
+	set.Bits[fd/32] |= 1 << (uint(fd) % 32)
+}
@@ -0,0 +1,11 @@
+//go:build linux
+
+package sysfs
+
+import "syscall"
+
+// fdSet sets the bit for `fd` in `set`. On Linux, syscall.FdSet.Bits is
+// indexed in words of 64 bits.
+func fdSet(set *syscall.FdSet, fd int) {
+	set.Bits[fd/64] |= 1 << (uint(fd) % 64)
+}
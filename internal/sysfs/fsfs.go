@@ -0,0 +1,255 @@
+package sysfs
+
+import (
+	"io"
+	"io/fs"
+	"path"
+	"syscall"
+
+	experimentalsys "github.com/tetratelabs/wazero/experimental/sys"
+	"github.com/tetratelabs/wazero/internal/fsapi"
+	"github.com/tetratelabs/wazero/sys"
+)
+
+// NewAdapterFS adapts `fsys` to a read-only fsapi.FS, so that any
+// implementation of io/fs.FS, including embed.FS, can be used as a WASI
+// preopen via ModuleConfig.WithFS. Optional stdlib interfaces are detected
+// and used when present: fs.StatFS for cheap Stat, fs.ReadDirFS for
+// directory listing, and fs.ReadDirFile on the open file for incremental
+// Readdir.
+//
+// # Notes
+//
+//   - The returned fsapi.FS is read-only: OpenFile rejects write flags with
+//     sys.EROFS, matching the read-only preopens used for embed.FS today.
+//   - Dev and Ino are not populated, as io/fs.FS has no stable device or
+//     inode concept.
+func NewAdapterFS(fsys fs.FS) fsapi.FS {
+	return &fsFS{fs: fsys}
+}
+
+type fsFS struct {
+	fsapi.UnimplementedFS
+
+	fs fs.FS
+}
+
+// OpenFile implements the same method as documented on fsapi.FS
+func (f *fsFS) OpenFile(name string, flag int, _ fs.FileMode) (fsapi.File, experimentalsys.Errno) {
+	if flag&(fsapi.O_WRONLY|fsapi.O_RDWR) != 0 {
+		return nil, experimentalsys.EROFS
+	}
+
+	name = cleanFSPath(name)
+	file, err := f.fs.Open(name)
+	if err != nil {
+		return nil, experimentalsys.UnwrapOSError(err)
+	}
+	return &fsFile{fs: f.fs, path: name, file: file}, 0
+}
+
+// Stat implements the same method as documented on fsapi.FS
+func (f *fsFS) Stat(name string) (sys.Stat_t, experimentalsys.Errno) {
+	name = cleanFSPath(name)
+	if statFS, ok := f.fs.(fs.StatFS); ok {
+		info, err := statFS.Stat(name)
+		if err != nil {
+			return sys.Stat_t{}, experimentalsys.UnwrapOSError(err)
+		}
+		return stat_tFromFileInfo(info), 0
+	}
+
+	info, err := fs.Stat(f.fs, name)
+	if err != nil {
+		return sys.Stat_t{}, experimentalsys.UnwrapOSError(err)
+	}
+	return stat_tFromFileInfo(info), 0
+}
+
+// Lstat implements the same method as documented on fsapi.FS
+//
+// io/fs.FS has no notion of symlinks, so this is the same as Stat.
+func (f *fsFS) Lstat(name string) (sys.Stat_t, experimentalsys.Errno) {
+	return f.Stat(name)
+}
+
+// cleanFSPath adapts WASI's absolute, slash-joined paths to the relative,
+// fs.ValidPath-constrained paths io/fs.FS requires.
+func cleanFSPath(name string) string {
+	name = path.Clean(name)
+	switch name {
+	case ".", "/":
+		return "."
+	default:
+		if len(name) > 0 && name[0] == '/' {
+			return name[1:]
+		}
+		return name
+	}
+}
+
+type fsFile struct {
+	fsapi.UnimplementedFile
+
+	fs   fs.FS
+	path string
+	file fs.File
+
+	// dirEntries buffers the remainder of a Readdir call when the
+	// underlying fs.File doesn't implement fs.ReadDirFile, so repeated
+	// calls still behave incrementally from the caller's perspective.
+	dirEntries []fs.DirEntry
+	// dirRead is true once dirEntries has been populated at least once,
+	// distinguishing "not yet loaded" from "loaded and now fully drained"
+	// so a later Readdir call returns the empty result that signals
+	// end-of-directory instead of re-listing from scratch.
+	dirRead bool
+}
+
+// IsDir implements the same method as documented on fsapi.File
+func (f *fsFile) IsDir() (bool, experimentalsys.Errno) {
+	info, err := f.file.Stat()
+	if err != nil {
+		return false, experimentalsys.UnwrapOSError(err)
+	}
+	return info.IsDir(), 0
+}
+
+// Stat implements the same method as documented on fsapi.File
+func (f *fsFile) Stat() (sys.Stat_t, experimentalsys.Errno) {
+	info, err := f.file.Stat()
+	if err != nil {
+		return sys.Stat_t{}, experimentalsys.UnwrapOSError(err)
+	}
+	return stat_tFromFileInfo(info), 0
+}
+
+// Read implements the same method as documented on fsapi.File
+func (f *fsFile) Read(buf []byte) (int, experimentalsys.Errno) {
+	n, err := f.file.Read(buf)
+	return n, experimentalsys.UnwrapOSError(err)
+}
+
+// Pread implements the same method as documented on fsapi.File
+func (f *fsFile) Pread(buf []byte, off int64) (int, experimentalsys.Errno) {
+	if ra, ok := f.file.(io.ReaderAt); ok {
+		n, err := ra.ReadAt(buf, off)
+		return n, experimentalsys.UnwrapOSError(err)
+	}
+	return 0, experimentalsys.ENOSYS
+}
+
+// Seek implements the same method as documented on fsapi.File
+func (f *fsFile) Seek(offset int64, whence int) (int64, experimentalsys.Errno) {
+	if s, ok := f.file.(io.Seeker); ok {
+		newOffset, err := s.Seek(offset, whence)
+		if err != nil {
+			return 0, experimentalsys.UnwrapOSError(err)
+		}
+		// Seeking resets any in-progress Readdir.
+		f.dirEntries = nil
+		f.dirRead = false
+		return newOffset, 0
+	}
+	return 0, experimentalsys.ENOSYS
+}
+
+// Readdir implements the same method as documented on fsapi.File
+func (f *fsFile) Readdir(n int) (dirents []fsapi.Dirent, errno experimentalsys.Errno) {
+	if rdf, ok := f.file.(fs.ReadDirFile); ok {
+		entries, err := rdf.ReadDir(n)
+		if err != nil && err != io.EOF {
+			// io.EOF means the directory is exhausted, which Readdir
+			// reports as a short (possibly empty) result with a zero
+			// errno, not an error.
+			return nil, experimentalsys.UnwrapOSError(err)
+		}
+		return direntsFromFSEntries(entries), 0
+	}
+
+	// No incremental reader: fall back to buffering the whole directory
+	// once via fs.ReadDirFS (or fs.ReadDir), then drain our own buffer.
+	// dirRead distinguishes "not yet loaded" from "loaded and drained": once
+	// true, an empty f.dirEntries means end-of-directory, not "reload me".
+	if !f.dirRead {
+		var entries []fs.DirEntry
+		var err error
+		if rdfs, ok := f.fs.(fs.ReadDirFS); ok {
+			entries, err = rdfs.ReadDir(f.path)
+		} else {
+			entries, err = fs.ReadDir(f.fs, f.path)
+		}
+		if err != nil {
+			return nil, experimentalsys.UnwrapOSError(err)
+		}
+		f.dirEntries = entries
+		f.dirRead = true
+	}
+
+	if n <= 0 {
+		entries := f.dirEntries
+		f.dirEntries = nil
+		return direntsFromFSEntries(entries), 0
+	}
+
+	if n > len(f.dirEntries) {
+		n = len(f.dirEntries)
+	}
+	entries := f.dirEntries[:n]
+	f.dirEntries = f.dirEntries[n:]
+	return direntsFromFSEntries(entries), 0
+}
+
+// Write implements the same method as documented on fsapi.File
+func (f *fsFile) Write([]byte) (int, experimentalsys.Errno) {
+	return 0, experimentalsys.ENOSYS
+}
+
+// Pwrite implements the same method as documented on fsapi.File
+func (f *fsFile) Pwrite([]byte, int64) (int, experimentalsys.Errno) {
+	return 0, experimentalsys.ENOSYS
+}
+
+// Truncate implements the same method as documented on fsapi.File
+func (f *fsFile) Truncate(int64) experimentalsys.Errno {
+	return experimentalsys.ENOSYS
+}
+
+// Utimens implements the same method as documented on fsapi.File
+func (f *fsFile) Utimens(*[2]syscall.Timespec) experimentalsys.Errno {
+	return experimentalsys.ENOSYS
+}
+
+// SetAppend implements the same method as documented on fsapi.File
+func (f *fsFile) SetAppend(bool) experimentalsys.Errno {
+	return experimentalsys.ENOSYS
+}
+
+// SetNonblock implements the same method as documented on fsapi.File
+func (f *fsFile) SetNonblock(bool) experimentalsys.Errno {
+	return experimentalsys.ENOSYS
+}
+
+// Close implements the same method as documented on fsapi.File
+func (f *fsFile) Close() experimentalsys.Errno {
+	return experimentalsys.UnwrapOSError(f.file.Close())
+}
+
+func direntsFromFSEntries(entries []fs.DirEntry) []fsapi.Dirent {
+	dirents := make([]fsapi.Dirent, len(entries))
+	for i, e := range entries {
+		dirents[i] = fsapi.Dirent{Name: e.Name(), Type: e.Type()}
+	}
+	return dirents
+}
+
+func stat_tFromFileInfo(info fs.FileInfo) sys.Stat_t {
+	return sys.Stat_t{
+		Mode:  info.Mode(),
+		Size:  info.Size(),
+		Mtim:  info.ModTime().UnixNano(),
+		Atim:  info.ModTime().UnixNano(),
+		Ctim:  info.ModTime().UnixNano(),
+		Nlink: 1,
+	}
+}
@@ -0,0 +1,14 @@
+//go:build !unix
+
+package sysfs
+
+import (
+	"os"
+	"syscall"
+)
+
+// pollFd has no portable implementation outside unix, so it keeps the
+// always-ready behavior PollRead had prior to pollableStdioFile.
+func pollFd(*os.File, syscall.RawConn, int32) (bool, error) {
+	return true, nil
+}
@@ -0,0 +1,75 @@
+//go:build unix
+
+package sysfs
+
+import (
+	"errors"
+	"os"
+	"syscall"
+	"time"
+)
+
+// pollFd blocks the calling goroutine, not an OS thread, until `f` has data
+// ready to read, has reached EOF (e.g. a pipe whose write end closed, or
+// Ctrl-D on a tty), or `timeoutMillis` elapses. See fsapi.File.PollRead for
+// the meaning of `timeoutMillis`.
+//
+// This relies on `f` already being non-blocking (see NewStdioFile), so that
+// `conn.Read`'s callback can probe readiness with a zero-timeout select(2)
+// and return false to ask the Go runtime to park this goroutine on its
+// network poller until the fd is readable, instead of dedicating an OS
+// thread to a blocking poll for the whole timeout.
+func pollFd(f *os.File, conn syscall.RawConn, timeoutMillis int32) (bool, error) {
+	switch {
+	case timeoutMillis < 0:
+		if err := f.SetReadDeadline(time.Time{}); err != nil {
+			return false, err
+		}
+	default:
+		deadline := time.Now().Add(time.Duration(timeoutMillis) * time.Millisecond)
+		if err := f.SetReadDeadline(deadline); err != nil {
+			return false, err
+		}
+		defer f.SetReadDeadline(time.Time{})
+	}
+
+	var ready bool
+	var selectErr error
+	err := conn.Read(func(fd uintptr) bool {
+		ready, selectErr = fdReadable(int(fd))
+		if selectErr != nil || ready {
+			return true // stop: either an error, or genuinely readable
+		}
+		// Not ready yet: returning false arms the runtime poller for this
+		// fd and parks the goroutine until it's readable (or the deadline
+		// set above elapses), rather than spinning or blocking a thread.
+		return false
+	})
+	if errors.Is(err, os.ErrDeadlineExceeded) {
+		return false, nil // timed out waiting: not ready, not an error
+	} else if err != nil {
+		return false, err
+	}
+	return ready, selectErr
+}
+
+// fdReadable reports whether `fd` is ready for reading via a zero-timeout
+// select(2). Unlike a bare FIONREAD byte-count check, select(2) also
+// reports a fd ready when it has reached EOF, matching POSIX poll's
+// POLLIN|POLLHUP behavior for a closed peer, so a guest polling a closed
+// stdin wakes immediately instead of waiting out the full timeout.
+func fdReadable(fd int) (bool, error) {
+	for {
+		var readFds syscall.FdSet
+		fdSet(&readFds, fd)
+		timeout := syscall.Timeval{}
+
+		n, err := syscall.Select(fd+1, &readFds, nil, nil, &timeout)
+		if err == syscall.EINTR {
+			continue
+		} else if err != nil {
+			return false, err
+		}
+		return n > 0, nil
+	}
+}
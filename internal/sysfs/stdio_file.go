@@ -0,0 +1,111 @@
+package sysfs
+
+import (
+	"io/fs"
+	"os"
+	"syscall"
+
+	experimentalsys "github.com/tetratelabs/wazero/experimental/sys"
+	"github.com/tetratelabs/wazero/internal/fsapi"
+	"github.com/tetratelabs/wazero/sys"
+)
+
+// NewStdioFile creates a fsapi.File for a standard I/O stream backed by an
+// *os.File. When `stdin` is true and `f` is pollable (a pipe, socket, or
+// tty), the returned file's PollRead honors its timeoutMillis argument by
+// using the runtime's network poller instead of busy-spinning or blocking a
+// whole OS thread; otherwise it keeps the always-ready behavior appropriate
+// for regular files and os.DevNull.
+//
+// The pollable path is never used for stdout/stderr (`stdin` false):
+// putting a write-side fd in O_NONBLOCK mode would let Write return EAGAIN
+// or a short write to the guest for an ordinary piped stdout, where the
+// prior blocking implementation never would.
+func NewStdioFile(stdin bool, f *os.File) (fsapi.File, error) {
+	if !stdin {
+		return &osFile{file: f}, nil
+	}
+
+	stat, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	if stat.Mode()&(fs.ModeNamedPipe|fs.ModeSocket|fs.ModeCharDevice) == 0 {
+		// Regular files are always ready: paying for SetNonblock and a
+		// SyscallConn round-trip would buy nothing.
+		return &osFile{file: f}, nil
+	}
+	if null, err := os.Stat(os.DevNull); err == nil && os.SameFile(stat, null) {
+		// os.DevNull is a character device, but like a regular file it is
+		// always ready.
+		return &osFile{file: f}, nil
+	}
+
+	conn, err := f.SyscallConn()
+	if err != nil {
+		// Not pollable the way we need (e.g. an unusual fs.File shim): fall
+		// back to the plain, always-blocking-on-Read implementation rather
+		// than failing to open stdio altogether.
+		return &osFile{file: f}, nil
+	}
+
+	var setErr error
+	if err := conn.Control(func(fd uintptr) {
+		setErr = syscall.SetNonblock(int(fd), true)
+	}); err != nil {
+		return &osFile{file: f}, nil
+	} else if setErr != nil {
+		return &osFile{file: f}, nil
+	}
+
+	return &pollableStdioFile{file: f, conn: conn}, nil
+}
+
+// pollableStdioFile is a fsapi.File backed by a nonblocking pipe, socket, or
+// tty *os.File, whose PollRead yields the calling goroutine to the Go
+// scheduler instead of spinning or dedicating an OS thread. It is only ever
+// used for stdin; see NewStdioFile.
+type pollableStdioFile struct {
+	fsapi.UnimplementedFile
+
+	file *os.File
+	conn syscall.RawConn
+}
+
+// Stat implements the same method as documented on fsapi.File
+func (f *pollableStdioFile) Stat() (sys.Stat_t, experimentalsys.Errno) {
+	info, err := f.file.Stat()
+	if err != nil {
+		return sys.Stat_t{}, experimentalsys.UnwrapOSError(err)
+	}
+	return stat_tFromFileInfo(info), 0
+}
+
+// IsDir implements the same method as documented on fsapi.File
+func (f *pollableStdioFile) IsDir() (bool, experimentalsys.Errno) {
+	return false, 0
+}
+
+// Read implements the same method as documented on fsapi.File
+func (f *pollableStdioFile) Read(buf []byte) (int, experimentalsys.Errno) {
+	n, err := f.file.Read(buf)
+	return n, experimentalsys.UnwrapOSError(err)
+}
+
+// PollRead implements the same method as documented on fsapi.File
+func (f *pollableStdioFile) PollRead(timeoutMillis int32) (ready bool, errno experimentalsys.Errno) {
+	ready, err := pollFd(f.file, f.conn, timeoutMillis)
+	if err != nil {
+		return false, experimentalsys.UnwrapOSError(err)
+	}
+	return ready, 0
+}
+
+// Close implements the same method as documented on fsapi.File
+//
+// Stdio streams are owned by the embedder, not this file, so Close is a
+// no-op, matching the prior StdinFile/writerFile behavior.
+func (f *pollableStdioFile) Close() experimentalsys.Errno {
+	return 0
+}